@@ -0,0 +1,44 @@
+package internalavm
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+)
+
+// GenesisAsset is a single asset definition within a Genesis.
+type GenesisAsset struct {
+	Alias         string `json:"alias"`
+	CreateAssetTx `serialize:"true"`
+}
+
+// ID derives a stable identifier for the asset from its alias, name and
+// symbol; Genesis orders its Txs by it.
+func (g *GenesisAsset) ID() ids.ID {
+	return hashing.ComputeHash256Array([]byte(g.Alias + g.Name + g.Symbol))
+}
+
+// Sort orders the asset's per-fx initial states by FxID, so that genesis
+// bytes don't depend on map iteration order upstream.
+func (g *GenesisAsset) Sort() {
+	sort.Slice(g.States, func(i, j int) bool {
+		return g.States[i].FxID < g.States[j].FxID
+	})
+}
+
+// Genesis is the set of assets created at the start of the chain.
+type Genesis struct {
+	Txs []*GenesisAsset `serialize:"true"`
+}
+
+// Sort orders Txs by their derived ID, not alphabetically by alias; the
+// result does not depend on the order the Txs were appended in.
+func (g *Genesis) Sort() {
+	sort.Slice(g.Txs, func(i, j int) bool {
+		iID := g.Txs[i].ID()
+		jID := g.Txs[j].ID()
+		return bytes.Compare(iID.Bytes(), jID.Bytes()) < 0
+	})
+}
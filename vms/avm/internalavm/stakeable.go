@@ -0,0 +1,26 @@
+package internalavm
+
+import (
+	"errors"
+
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+)
+
+var errNilTransferOut = errors.New("nil transfer output wrapped by locked output")
+
+// StakeableLockOut wraps another output, making it unspendable until
+// Locktime has passed. It is used to deliver locked genesis allocations
+// (e.g. foundation treasury, team vesting) without any staking involved.
+type StakeableLockOut struct {
+	Locktime    uint64       `serialize:"true" json:"locktime"`
+	TransferOut verify.State `serialize:"true" json:"output"`
+}
+
+// Verify returns an error if the locked output is malformed or wraps a
+// malformed output.
+func (s *StakeableLockOut) Verify() error {
+	if s.TransferOut == nil {
+		return errNilTransferOut
+	}
+	return s.TransferOut.Verify()
+}
@@ -0,0 +1,80 @@
+// Package internalavm holds the AVM's unsigned transaction and genesis
+// types, shared by the static genesis builder and the running VM.
+package internalavm
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/ava-labs/avalanchego/utils/codec"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+)
+
+// codecVersion is the codec version genesis initial states are marshaled
+// with when ordering their outputs deterministically.
+const codecVersion = uint16(0)
+
+// BaseTx is the common body of every AVM transaction.
+type BaseTx struct {
+	avax.BaseTx `serialize:"true"`
+}
+
+// CreateAssetTx creates a new asset, optionally distributing an initial
+// state of outputs to it.
+type CreateAssetTx struct {
+	BaseTx       `serialize:"true"`
+	Name         string          `serialize:"true" json:"name"`
+	Symbol       string          `serialize:"true" json:"symbol"`
+	Denomination byte            `serialize:"true" json:"denomination"`
+	States       []*InitialState `serialize:"true" json:"initialStates"`
+}
+
+// Operation spends a set of inputs under a single Fx, producing a new
+// Fx-specific state transition (e.g. minting).
+type Operation struct {
+	Ins  []*avax.TransferableInput `serialize:"true" json:"inputs"`
+	FxID uint32                    `serialize:"true" json:"fxID"`
+	Op   verify.State              `serialize:"true" json:"operation"`
+}
+
+// OperationTx executes a set of Operations.
+type OperationTx struct {
+	BaseTx `serialize:"true"`
+	Ops    []*Operation `serialize:"true" json:"operations"`
+}
+
+// ImportTx moves assets from another chain onto this one.
+type ImportTx struct {
+	BaseTx      `serialize:"true"`
+	ImportedIns []*avax.TransferableInput `serialize:"true" json:"importedInputs"`
+}
+
+// ExportTx moves assets from this chain onto another one.
+type ExportTx struct {
+	BaseTx       `serialize:"true"`
+	ExportedOuts []*avax.TransferableOutput `serialize:"true" json:"exportedOutputs"`
+}
+
+// InitialState is the set of UTXOs a single Fx produces for a newly created
+// asset.
+type InitialState struct {
+	FxID uint32         `serialize:"true" json:"fxIndex"`
+	Outs []verify.State `serialize:"true" json:"outputs"`
+}
+
+// Sort orders Outs deterministically by their marshaled bytes, so that two
+// builds from the same logical inputs produce identical genesis bytes.
+func (is *InitialState) Sort(c codec.Manager) {
+	sort.Slice(is.Outs, func(i, j int) bool {
+		iBytes, err := c.Marshal(codecVersion, &is.Outs[i])
+		if err != nil {
+			return false
+		}
+		jBytes, err := c.Marshal(codecVersion, &is.Outs[j])
+		if err != nil {
+			return false
+		}
+		return bytes.Compare(iBytes, jBytes) < 0
+	})
+}
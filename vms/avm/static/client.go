@@ -0,0 +1,54 @@
+package static
+
+import (
+	"context"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/ava-labs/avalanchego/vms/avm/vmargs"
+)
+
+// Client for interacting with the static AVM service, served at
+// /ext/vm/avm before any AVM-based chain has been created.
+type Client struct {
+	requester rpc.EndpointRequester
+}
+
+// NewClient returns a Client for the static AVM service at [uri], issuing
+// requests with [requestTimeout] as the per-call timeout.
+func NewClient(uri string, requestTimeout time.Duration) *Client {
+	return &Client{
+		requester: rpc.NewEndpointRequester(uri, "/ext/vm/avm", "avm", requestTimeout),
+	}
+}
+
+// BuildGenesis builds the genesis bytes for an AVM-based chain from
+// [genesisData], returning the decoded bytes and the encoding the server
+// used to produce them. [networkStartTime] rejects any locked holder whose
+// locktime falls before it, and [expectedGenesisID], when non-empty, fails
+// the call if the computed genesis ID doesn't match.
+func (c *Client) BuildGenesis(
+	ctx context.Context,
+	networkID uint32,
+	networkStartTime int64,
+	encoding formatting.Encoding,
+	genesisData map[string]vmargs.AssetDefinition,
+	expectedGenesisID ids.ID,
+) ([]byte, formatting.Encoding, error) {
+	res := &vmargs.BuildGenesisReply{}
+	if err := c.requester.SendRequest(ctx, "buildGenesis", &vmargs.BuildGenesisArgs{
+		NetworkID:         json.Uint32(networkID),
+		NetworkStartTime:  networkStartTime,
+		GenesisData:       genesisData,
+		Encoding:          encoding,
+		ExpectedGenesisID: expectedGenesisID,
+	}, res); err != nil {
+		return nil, formatting.Hex, err
+	}
+
+	b, err := formatting.Decode(res.Encoding, res.Bytes)
+	return b, res.Encoding, err
+}
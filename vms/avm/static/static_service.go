@@ -5,53 +5,73 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"sort"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/codec"
+	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	apijson "github.com/ava-labs/avalanchego/utils/json"
+	safemath "github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/vms/avm/internalavm"
 	"github.com/ava-labs/avalanchego/vms/avm/vmargs"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/nftfx"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 )
 
 var (
-	errUnknownAssetType = errors.New("unknown asset type")
-	codecVersion        = uint16(0)
+	errUnknownAssetType  = errors.New("unknown asset type")
+	errInvalidThreshold  = errors.New("threshold must be no greater than number of addresses")
+	errLocktimeInThePast = errors.New("locktime is in the past relative to the network start time")
+	errGenesisIDMismatch = errors.New("computed genesis ID does not match expected genesis ID")
+	errEmptyMinterSet    = errors.New("variable cap asset declares no reachable minters")
+	errSupplyOverflow    = errors.New("initial supply overflows at the asset's denomination")
+	errSupplyExceedsMax  = errors.New("initial supply exceeds the asset's max supply")
+	codecVersion         = uint16(0)
+
+	// fxNames is the order in which fxs are registered on the codec manager;
+	// an fx's position here is its FxID.
+	fxNames = []string{"secp256k1fx", "nftfx"}
+
+	// assetTypeFx maps an InitialState asset type to the fx that produces it.
+	assetTypeFx = map[string]string{
+		"fixedCap":    "secp256k1fx",
+		"variableCap": "secp256k1fx",
+		"nft":         "nftfx",
+	}
 )
 
 // BuildGenesis returns the UTXOs such that at least one address in [args.Addresses] is
 // referenced in the UTXO.
 func BuildGenesis(args *vmargs.BuildGenesisArgs, reply *vmargs.BuildGenesisReply) error {
-	errs := wrappers.Errs{}
+	manager, err := newCodecManager()
+	if err != nil {
+		return err
+	}
 
-	c := codec.New(codec.DefaultTagName, 1<<20)
-	manager := codec.NewManager(math.MaxUint32)
-	errs.Add(
-		c.RegisterType(&internalavm.BaseTx{}),
-		c.RegisterType(&internalavm.CreateAssetTx{}),
-		c.RegisterType(&internalavm.OperationTx{}),
-		c.RegisterType(&internalavm.ImportTx{}),
-		c.RegisterType(&internalavm.ExportTx{}),
-		c.RegisterType(&secp256k1fx.TransferInput{}),
-		c.RegisterType(&secp256k1fx.MintOutput{}),
-		c.RegisterType(&secp256k1fx.TransferOutput{}),
-		c.RegisterType(&secp256k1fx.MintOperation{}),
-		c.RegisterType(&secp256k1fx.Credential{}),
-		manager.RegisterCodec(codecVersion, c),
-	)
-	if errs.Errored() {
-		return errs.Err
+	fxIDs := make(map[string]uint32, len(fxNames))
+	for i, name := range fxNames {
+		fxIDs[name] = uint32(i)
 	}
 
+	assetAliases := make([]string, 0, len(args.GenesisData))
+	for assetAlias := range args.GenesisData {
+		assetAliases = append(assetAliases, assetAlias)
+	}
+	sort.Strings(assetAliases)
+
 	g := internalavm.Genesis{}
-	for assetAlias, assetDefinition := range args.GenesisData {
+	for _, assetAlias := range assetAliases {
+		assetDefinition := args.GenesisData[assetAlias]
 		assetMemo, err := formatting.Decode(args.Encoding, assetDefinition.Memo)
 
 		if err != nil {
 			return fmt.Errorf("problem formatting asset definition memo due to: %w", err)
 		}
+		denomination := byte(assetDefinition.Denomination)
 		asset := internalavm.GenesisAsset{
 			Alias: assetAlias,
 			CreateAssetTx: internalavm.CreateAssetTx{
@@ -62,14 +82,31 @@ func BuildGenesis(args *vmargs.BuildGenesisArgs, reply *vmargs.BuildGenesisReply
 				}},
 				Name:         assetDefinition.Name,
 				Symbol:       assetDefinition.Symbol,
-				Denomination: byte(assetDefinition.Denomination),
+				Denomination: denomination,
 			},
 		}
 		if len(assetDefinition.InitialState) > 0 {
-			initialState := &internalavm.InitialState{
-				FxID: 0, // TODO: Should lookup secp256k1fx FxID
+			var fixedCapSupply uint64
+			statesByFx := make(map[uint32]*internalavm.InitialState)
+			initialStateForAssetType := func(assetType string) (*internalavm.InitialState, error) {
+				fxName, ok := assetTypeFx[assetType]
+				if !ok {
+					return nil, errUnknownAssetType
+				}
+				fxID := fxIDs[fxName]
+				state, ok := statesByFx[fxID]
+				if !ok {
+					state = &internalavm.InitialState{FxID: fxID}
+					statesByFx[fxID] = state
+				}
+				return state, nil
 			}
+
 			for assetType, initialStates := range assetDefinition.InitialState {
+				initialState, err := initialStateForAssetType(assetType)
+				if err != nil {
+					return err
+				}
 				switch assetType {
 				case "fixedCap":
 					for _, state := range initialStates {
@@ -81,21 +118,51 @@ func BuildGenesis(args *vmargs.BuildGenesisArgs, reply *vmargs.BuildGenesisReply
 						if err := json.Unmarshal(b, &holder); err != nil {
 							return fmt.Errorf("problem unmarshaling holder: %w", err)
 						}
-						_, addrbuff, err := formatting.ParseBech32(holder.Address)
-						if err != nil {
-							return fmt.Errorf("problem parsing holder address: %w", err)
+						if int(holder.Threshold) > len(holder.Addresses) {
+							return fmt.Errorf("%w: threshold %d > %d addresses", errInvalidThreshold, holder.Threshold, len(holder.Addresses))
 						}
-						addr, err := ids.ToShortID(addrbuff)
-						if err != nil {
-							return fmt.Errorf("problem parsing holder address: %w", err)
+						threshold := holder.Threshold
+						if threshold == 0 {
+							threshold = 1
 						}
-						initialState.Outs = append(initialState.Outs, &secp256k1fx.TransferOutput{
+						var overflowErr error
+						fixedCapSupply, overflowErr = safemath.Add64(fixedCapSupply, uint64(holder.Amount))
+						if overflowErr != nil {
+							return fmt.Errorf("%w: %s", errSupplyOverflow, overflowErr)
+						}
+						addrs := make([]ids.ShortID, len(holder.Addresses))
+						for i, addrStr := range holder.Addresses {
+							_, addrbuff, err := formatting.ParseBech32(addrStr)
+							if err != nil {
+								return fmt.Errorf("problem parsing holder address: %w", err)
+							}
+							addr, err := ids.ToShortID(addrbuff)
+							if err != nil {
+								return fmt.Errorf("problem parsing holder address: %w", err)
+							}
+							addrs[i] = addr
+						}
+
+						transferOutput := &secp256k1fx.TransferOutput{
 							Amt: uint64(holder.Amount),
 							OutputOwners: secp256k1fx.OutputOwners{
-								Threshold: 1,
-								Addrs:     []ids.ShortID{addr},
+								Threshold: threshold,
+								Addrs:     addrs,
 							},
-						})
+						}
+						transferOutput.OutputOwners.Sort()
+
+						if holder.Locktime > 0 {
+							if int64(holder.Locktime) < args.NetworkStartTime {
+								return fmt.Errorf("%w: locktime %d is before network start time %d", errLocktimeInThePast, holder.Locktime, args.NetworkStartTime)
+							}
+							initialState.Outs = append(initialState.Outs, &internalavm.StakeableLockOut{
+								Locktime:    holder.Locktime,
+								TransferOut: transferOutput,
+							})
+						} else {
+							initialState.Outs = append(initialState.Outs, transferOutput)
+						}
 					}
 				case "variableCap":
 					for _, state := range initialStates {
@@ -107,10 +174,20 @@ func BuildGenesis(args *vmargs.BuildGenesisArgs, reply *vmargs.BuildGenesisReply
 						if err := json.Unmarshal(b, &owners); err != nil {
 							return fmt.Errorf("problem unmarshaling Owners: %w", err)
 						}
+						if len(owners.Minters) == 0 {
+							return errEmptyMinterSet
+						}
+						if int(owners.Threshold) > len(owners.Minters) {
+							return fmt.Errorf("%w: threshold %d > %d minters", errInvalidThreshold, owners.Threshold, len(owners.Minters))
+						}
+						threshold := owners.Threshold
+						if threshold == 0 {
+							threshold = 1
+						}
 
 						out := &secp256k1fx.MintOutput{
 							OutputOwners: secp256k1fx.OutputOwners{
-								Threshold: 1,
+								Threshold: threshold,
 							},
 						}
 						for _, address := range owners.Minters {
@@ -128,12 +205,71 @@ func BuildGenesis(args *vmargs.BuildGenesisArgs, reply *vmargs.BuildGenesisReply
 
 						initialState.Outs = append(initialState.Outs, out)
 					}
-				default:
-					return errUnknownAssetType
+				case "nft":
+					for _, state := range initialStates {
+						b, err := json.Marshal(state)
+						if err != nil {
+							return fmt.Errorf("problem marshaling state: %w", err)
+						}
+						nftHolder := vmargs.NFTHolder{}
+						if err := json.Unmarshal(b, &nftHolder); err != nil {
+							return fmt.Errorf("problem unmarshaling NFT holder: %w", err)
+						}
+						if int(nftHolder.Threshold) > len(nftHolder.Owners) {
+							return fmt.Errorf("%w: threshold %d > %d owners", errInvalidThreshold, nftHolder.Threshold, len(nftHolder.Owners))
+						}
+						threshold := nftHolder.Threshold
+						if threshold == 0 {
+							threshold = 1
+						}
+						payload, err := formatting.Decode(args.Encoding, nftHolder.Payload)
+						if err != nil {
+							return fmt.Errorf("problem decoding NFT payload: %w", err)
+						}
+
+						addrs := make([]ids.ShortID, len(nftHolder.Owners))
+						for i, addrStr := range nftHolder.Owners {
+							_, addrbuff, err := formatting.ParseBech32(addrStr)
+							if err != nil {
+								return fmt.Errorf("problem parsing NFT owner address: %w", err)
+							}
+							addr, err := ids.ToShortID(addrbuff)
+							if err != nil {
+								return fmt.Errorf("problem parsing NFT owner address: %w", err)
+							}
+							addrs[i] = addr
+						}
+
+						out := &nftfx.MintOutput{
+							GroupID: nftHolder.GroupID,
+							Payload: payload,
+							OutputOwners: secp256k1fx.OutputOwners{
+								Threshold: threshold,
+								Addrs:     addrs,
+							},
+						}
+						out.OutputOwners.Sort()
+
+						initialState.Outs = append(initialState.Outs, out)
+					}
 				}
 			}
-			initialState.Sort(manager)
-			asset.States = append(asset.States, initialState)
+			// Append the per-fx initial states in registration order for determinism.
+			for fxID := uint32(0); fxID < uint32(len(fxNames)); fxID++ {
+				initialState, ok := statesByFx[fxID]
+				if !ok {
+					continue
+				}
+				initialState.Sort(manager)
+				asset.States = append(asset.States, initialState)
+			}
+
+			if maxRepresentable := maxSupplyForDenomination(denomination); fixedCapSupply > maxRepresentable {
+				return fmt.Errorf("%w: %d > %d at denomination %d", errSupplyOverflow, fixedCapSupply, maxRepresentable, denomination)
+			}
+			if maxSupply := uint64(assetDefinition.MaxSupply); maxSupply != 0 && fixedCapSupply > maxSupply {
+				return fmt.Errorf("%w: %d > %d", errSupplyExceedsMax, fixedCapSupply, maxSupply)
+			}
 		}
 		asset.Sort()
 		g.Txs = append(g.Txs, &asset)
@@ -145,10 +281,195 @@ func BuildGenesis(args *vmargs.BuildGenesisArgs, reply *vmargs.BuildGenesisReply
 		return fmt.Errorf("problem marshaling genesis: %w", err)
 	}
 
+	genesisID := hashing.ComputeHash256Array(b)
+	if args.ExpectedGenesisID != ids.Empty && genesisID != args.ExpectedGenesisID {
+		return fmt.Errorf("%w: expected %s, got %s", errGenesisIDMismatch, args.ExpectedGenesisID, genesisID)
+	}
+
 	reply.Bytes, err = formatting.Encode(args.Encoding, b)
 	if err != nil {
 		return fmt.Errorf("couldn't encode genesis as string: %s", err)
 	}
 	reply.Encoding = args.Encoding
+	reply.GenesisID = genesisID
+	return nil
+}
+
+// maxSupplyForDenomination returns the largest supply that can be expressed
+// without overflowing a uint64 once scaled by 10^denomination.
+func maxSupplyForDenomination(denomination byte) uint64 {
+	limit := uint64(math.MaxUint64)
+	for i := byte(0); i < denomination; i++ {
+		limit /= 10
+	}
+	return limit
+}
+
+// newCodecManager returns the codec manager used to marshal and unmarshal
+// genesis blocks, with every type BuildGenesis and ParseGenesis need to
+// agree on registered under the same codec version.
+func newCodecManager() (codec.Manager, error) {
+	errs := wrappers.Errs{}
+
+	c := codec.New(codec.DefaultTagName, 1<<20)
+	manager := codec.NewManager(math.MaxUint32)
+	errs.Add(
+		c.RegisterType(&internalavm.BaseTx{}),
+		c.RegisterType(&internalavm.CreateAssetTx{}),
+		c.RegisterType(&internalavm.OperationTx{}),
+		c.RegisterType(&internalavm.ImportTx{}),
+		c.RegisterType(&internalavm.ExportTx{}),
+		c.RegisterType(&internalavm.StakeableLockOut{}),
+		c.RegisterType(&secp256k1fx.TransferInput{}),
+		c.RegisterType(&secp256k1fx.MintOutput{}),
+		c.RegisterType(&secp256k1fx.TransferOutput{}),
+		c.RegisterType(&secp256k1fx.MintOperation{}),
+		c.RegisterType(&secp256k1fx.Credential{}),
+		c.RegisterType(&nftfx.MintOutput{}),
+		c.RegisterType(&nftfx.TransferOutput{}),
+		c.RegisterType(&nftfx.MintOperation{}),
+		c.RegisterType(&nftfx.Credential{}),
+		c.RegisterType(&nftfx.TransferOperation{}),
+		manager.RegisterCodec(codecVersion, c),
+	)
+	if errs.Errored() {
+		return nil, errs.Err
+	}
+	return manager, nil
+}
+
+// ParseGenesis decodes a genesis blob produced by BuildGenesis back into the
+// GenesisData schema a caller would have submitted, for debugging, migration
+// and subnet tooling.
+func ParseGenesis(args *vmargs.ParseGenesisArgs, reply *vmargs.ParseGenesisReply) error {
+	manager, err := newCodecManager()
+	if err != nil {
+		return err
+	}
+
+	b, err := formatting.Decode(args.Encoding, args.Bytes)
+	if err != nil {
+		return fmt.Errorf("problem decoding genesis bytes: %w", err)
+	}
+
+	g := internalavm.Genesis{}
+	if _, err := manager.Unmarshal(b, &g); err != nil {
+		return fmt.Errorf("problem parsing genesis bytes: %w", err)
+	}
+
+	hrp := constants.GetHRP(uint32(args.NetworkID))
+	genesisData := make(map[string]vmargs.AssetDefinition, len(g.Txs))
+	for _, tx := range g.Txs {
+		assetMemo, err := formatting.Encode(args.Encoding, tx.Memo)
+		if err != nil {
+			return fmt.Errorf("problem formatting asset memo: %w", err)
+		}
+		assetDefinition := vmargs.AssetDefinition{
+			Name:         tx.Name,
+			Symbol:       tx.Symbol,
+			Denomination: apijson.Uint32(tx.Denomination),
+			Memo:         assetMemo,
+			InitialState: make(map[string][]interface{}),
+		}
+
+		for _, state := range tx.States {
+			for _, out := range state.Outs {
+				switch out := out.(type) {
+				case *secp256k1fx.TransferOutput:
+					holder, err := toHolder(hrp, out.Amt, &out.OutputOwners, 0)
+					if err != nil {
+						return err
+					}
+					assetDefinition.InitialState["fixedCap"] = append(assetDefinition.InitialState["fixedCap"], holder)
+				case *internalavm.StakeableLockOut:
+					transferOutput, ok := out.TransferOut.(*secp256k1fx.TransferOutput)
+					if !ok {
+						return fmt.Errorf("unexpected locked output type %T", out.TransferOut)
+					}
+					holder, err := toHolder(hrp, transferOutput.Amt, &transferOutput.OutputOwners, out.Locktime)
+					if err != nil {
+						return err
+					}
+					assetDefinition.InitialState["fixedCap"] = append(assetDefinition.InitialState["fixedCap"], holder)
+				case *secp256k1fx.MintOutput:
+					owners, err := toOwners(hrp, &out.OutputOwners)
+					if err != nil {
+						return err
+					}
+					assetDefinition.InitialState["variableCap"] = append(assetDefinition.InitialState["variableCap"], owners)
+				case *nftfx.MintOutput:
+					nftHolder, err := toNFTHolder(hrp, args.Encoding, out)
+					if err != nil {
+						return err
+					}
+					assetDefinition.InitialState["nft"] = append(assetDefinition.InitialState["nft"], nftHolder)
+				default:
+					return fmt.Errorf("unknown initial state output type %T", out)
+				}
+			}
+		}
+
+		genesisData[tx.Alias] = assetDefinition
+	}
+
+	reply.GenesisData = genesisData
+	reply.Encoding = args.Encoding
 	return nil
+}
+
+// toHolder reconstructs a vmargs.Holder from a secp256k1fx output's owners,
+// optionally recovering the locktime of a StakeableLockOut-equivalent.
+func toHolder(hrp string, amt uint64, owners *secp256k1fx.OutputOwners, locktime uint64) (vmargs.Holder, error) {
+	addresses := make([]string, len(owners.Addrs))
+	for i, addr := range owners.Addrs {
+		addrStr, err := formatting.FormatBech32(hrp, addr.Bytes())
+		if err != nil {
+			return vmargs.Holder{}, fmt.Errorf("problem formatting holder address: %w", err)
+		}
+		addresses[i] = addrStr
+	}
+	return vmargs.Holder{
+		Amount:    apijson.Uint64(amt),
+		Addresses: addresses,
+		Threshold: owners.Threshold,
+		Locktime:  locktime,
+	}, nil
+}
+
+// toOwners reconstructs a vmargs.Owners from a variableCap mint output's owners.
+func toOwners(hrp string, owners *secp256k1fx.OutputOwners) (vmargs.Owners, error) {
+	minters := make([]string, len(owners.Addrs))
+	for i, addr := range owners.Addrs {
+		addrStr, err := formatting.FormatBech32(hrp, addr.Bytes())
+		if err != nil {
+			return vmargs.Owners{}, fmt.Errorf("problem formatting minter address: %w", err)
+		}
+		minters[i] = addrStr
+	}
+	return vmargs.Owners{
+		Minters:   minters,
+		Threshold: owners.Threshold,
+	}, nil
+}
+
+// toNFTHolder reconstructs a vmargs.NFTHolder from an nftfx mint output.
+func toNFTHolder(hrp string, encoding formatting.Encoding, out *nftfx.MintOutput) (vmargs.NFTHolder, error) {
+	ownerStrs := make([]string, len(out.Addrs))
+	for i, addr := range out.Addrs {
+		addrStr, err := formatting.FormatBech32(hrp, addr.Bytes())
+		if err != nil {
+			return vmargs.NFTHolder{}, fmt.Errorf("problem formatting NFT owner address: %w", err)
+		}
+		ownerStrs[i] = addrStr
+	}
+	payload, err := formatting.Encode(encoding, out.Payload)
+	if err != nil {
+		return vmargs.NFTHolder{}, fmt.Errorf("problem formatting NFT payload: %w", err)
+	}
+	return vmargs.NFTHolder{
+		GroupID:   out.GroupID,
+		Payload:   payload,
+		Owners:    ownerStrs,
+		Threshold: out.Threshold,
+	}, nil
 }
\ No newline at end of file
@@ -0,0 +1,504 @@
+package static
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	apijson "github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/vms/avm/vmargs"
+)
+
+func testAddress(t *testing.T, seed byte) string {
+	t.Helper()
+	addrStr, err := formatting.FormatBech32("local", ids.ShortID{seed}.Bytes())
+	require.NoError(t, err)
+	return addrStr
+}
+
+func TestBuildGenesisHolderValidation(t *testing.T) {
+	addr := testAddress(t, 1)
+
+	tests := []struct {
+		name    string
+		holder  map[string]interface{}
+		start   int64
+		wantErr error
+	}{
+		{
+			name: "threshold greater than address count",
+			holder: map[string]interface{}{
+				"amount":    "1",
+				"addresses": []string{addr},
+				"threshold": 2,
+			},
+			wantErr: errInvalidThreshold,
+		},
+		{
+			name: "locktime before network start time",
+			holder: map[string]interface{}{
+				"amount":    "1",
+				"addresses": []string{addr},
+				"locktime":  1,
+			},
+			start:   1000,
+			wantErr: errLocktimeInThePast,
+		},
+		{
+			name: "single address with no threshold is accepted",
+			holder: map[string]interface{}{
+				"amount":    "1",
+				"addresses": []string{addr},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := &vmargs.BuildGenesisArgs{
+				Encoding:         formatting.Hex,
+				NetworkStartTime: tt.start,
+				GenesisData: map[string]vmargs.AssetDefinition{
+					"asset1": {
+						Name:   "asset1",
+						Symbol: "AST",
+						Memo:   "0x",
+						InitialState: map[string][]interface{}{
+							"fixedCap": {tt.holder},
+						},
+					},
+				},
+			}
+			reply := &vmargs.BuildGenesisReply{}
+			err := BuildGenesis(args, reply)
+			if tt.wantErr == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func simpleGenesisArgs(addr string) *vmargs.BuildGenesisArgs {
+	return &vmargs.BuildGenesisArgs{
+		Encoding: formatting.Hex,
+		GenesisData: map[string]vmargs.AssetDefinition{
+			"asset1": {
+				Name:   "asset1",
+				Symbol: "AST",
+				Memo:   "0x",
+				InitialState: map[string][]interface{}{
+					"fixedCap": {
+						map[string]interface{}{
+							"amount":    "1",
+							"addresses": []string{addr},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestBuildGenesisIsDeterministic(t *testing.T) {
+	addr := testAddress(t, 1)
+
+	reply1 := &vmargs.BuildGenesisReply{}
+	require.NoError(t, BuildGenesis(simpleGenesisArgs(addr), reply1))
+
+	reply2 := &vmargs.BuildGenesisReply{}
+	require.NoError(t, BuildGenesis(simpleGenesisArgs(addr), reply2))
+
+	require.Equal(t, reply1.Bytes, reply2.Bytes)
+	require.Equal(t, reply1.GenesisID, reply2.GenesisID)
+}
+
+func TestBuildGenesisExpectedGenesisIDMismatch(t *testing.T) {
+	addr := testAddress(t, 1)
+
+	args := simpleGenesisArgs(addr)
+	args.ExpectedGenesisID = ids.ID{1, 2, 3}
+
+	reply := &vmargs.BuildGenesisReply{}
+	err := BuildGenesis(args, reply)
+	require.ErrorIs(t, err, errGenesisIDMismatch)
+}
+
+func TestBuildGenesisExpectedGenesisIDMatch(t *testing.T) {
+	addr := testAddress(t, 1)
+
+	reply := &vmargs.BuildGenesisReply{}
+	require.NoError(t, BuildGenesis(simpleGenesisArgs(addr), reply))
+
+	args := simpleGenesisArgs(addr)
+	args.ExpectedGenesisID = reply.GenesisID
+
+	reply2 := &vmargs.BuildGenesisReply{}
+	require.NoError(t, BuildGenesis(args, reply2))
+}
+
+func TestBuildGenesisSupplyInvariants(t *testing.T) {
+	addr := testAddress(t, 1)
+
+	tests := []struct {
+		name         string
+		maxSupply    uint64
+		denomination uint32
+		amounts      []string
+		wantErr      error
+	}{
+		{
+			name:      "sum exceeds declared max supply",
+			maxSupply: 10,
+			amounts:   []string{"5", "6"},
+			wantErr:   errSupplyExceedsMax,
+		},
+		{
+			name:         "sum overflows at the declared denomination",
+			denomination: 19,
+			amounts:      []string{"18446744073709551615"},
+			wantErr:      errSupplyOverflow,
+		},
+		{
+			name:      "within max supply",
+			maxSupply: 10,
+			amounts:   []string{"5", "5"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			holders := make([]interface{}, len(tt.amounts))
+			for i, amt := range tt.amounts {
+				holders[i] = map[string]interface{}{
+					"amount":    amt,
+					"addresses": []string{addr},
+				}
+			}
+			args := &vmargs.BuildGenesisArgs{
+				Encoding: formatting.Hex,
+				GenesisData: map[string]vmargs.AssetDefinition{
+					"asset1": {
+						Name:         "asset1",
+						Symbol:       "AST",
+						Memo:         "0x",
+						MaxSupply:    apijson.Uint64(tt.maxSupply),
+						Denomination: apijson.Uint32(tt.denomination),
+						InitialState: map[string][]interface{}{
+							"fixedCap": holders,
+						},
+					},
+				},
+			}
+			reply := &vmargs.BuildGenesisReply{}
+			err := BuildGenesis(args, reply)
+			if tt.wantErr == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestBuildGenesisEmptyMinterSet(t *testing.T) {
+	args := &vmargs.BuildGenesisArgs{
+		Encoding: formatting.Hex,
+		GenesisData: map[string]vmargs.AssetDefinition{
+			"asset1": {
+				Name:   "asset1",
+				Symbol: "AST",
+				Memo:   "0x",
+				InitialState: map[string][]interface{}{
+					"variableCap": {
+						map[string]interface{}{
+							"minters": []string{},
+						},
+					},
+				},
+			},
+		},
+	}
+	reply := &vmargs.BuildGenesisReply{}
+	err := BuildGenesis(args, reply)
+	require.ErrorIs(t, err, errEmptyMinterSet)
+}
+
+func TestBuildGenesisVariableCapThreshold(t *testing.T) {
+	addr1 := testAddress(t, 1)
+	addr2 := testAddress(t, 2)
+	addr3 := testAddress(t, 3)
+
+	tests := []struct {
+		name      string
+		minters   []string
+		threshold interface{}
+		wantErr   error
+	}{
+		{
+			name:      "threshold greater than minter count",
+			minters:   []string{addr1, addr2},
+			threshold: 3,
+			wantErr:   errInvalidThreshold,
+		},
+		{
+			name:    "no threshold is accepted",
+			minters: []string{addr1, addr2, addr3},
+		},
+		{
+			name:      "threshold matching minter count",
+			minters:   []string{addr1, addr2, addr3},
+			threshold: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			owners := map[string]interface{}{
+				"minters": tt.minters,
+			}
+			if tt.threshold != nil {
+				owners["threshold"] = tt.threshold
+			}
+			args := &vmargs.BuildGenesisArgs{
+				Encoding: formatting.Hex,
+				GenesisData: map[string]vmargs.AssetDefinition{
+					"asset1": {
+						Name:   "asset1",
+						Symbol: "AST",
+						Memo:   "0x",
+						InitialState: map[string][]interface{}{
+							"variableCap": {owners},
+						},
+					},
+				},
+			}
+			reply := &vmargs.BuildGenesisReply{}
+			err := BuildGenesis(args, reply)
+			if tt.wantErr == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestBuildGenesisNFTHolderValidation(t *testing.T) {
+	addr1 := testAddress(t, 1)
+	addr2 := testAddress(t, 2)
+
+	tests := []struct {
+		name    string
+		nft     map[string]interface{}
+		wantErr error
+	}{
+		{
+			name: "threshold greater than owner count",
+			nft: map[string]interface{}{
+				"groupID":   1,
+				"payload":   "0x",
+				"owners":    []string{addr1},
+				"threshold": 2,
+			},
+			wantErr: errInvalidThreshold,
+		},
+		{
+			name: "single owner with no threshold is accepted",
+			nft: map[string]interface{}{
+				"groupID": 1,
+				"payload": "0x",
+				"owners":  []string{addr1},
+			},
+		},
+		{
+			name: "multiple owners with explicit threshold",
+			nft: map[string]interface{}{
+				"groupID":   1,
+				"payload":   "0x",
+				"owners":    []string{addr1, addr2},
+				"threshold": 2,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := &vmargs.BuildGenesisArgs{
+				Encoding: formatting.Hex,
+				GenesisData: map[string]vmargs.AssetDefinition{
+					"asset1": {
+						Name:   "asset1",
+						Symbol: "AST",
+						Memo:   "0x",
+						InitialState: map[string][]interface{}{
+							"nft": {tt.nft},
+						},
+					},
+				},
+			}
+			reply := &vmargs.BuildGenesisReply{}
+			err := BuildGenesis(args, reply)
+			if tt.wantErr == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestBuildGenesisNFTRegistersNftfx(t *testing.T) {
+	addr := testAddress(t, 1)
+
+	args := &vmargs.BuildGenesisArgs{
+		Encoding: formatting.Hex,
+		GenesisData: map[string]vmargs.AssetDefinition{
+			"asset1": {
+				Name:   "asset1",
+				Symbol: "AST",
+				Memo:   "0x",
+				InitialState: map[string][]interface{}{
+					"nft": {
+						map[string]interface{}{
+							"groupID": 1,
+							"payload": "0x",
+							"owners":  []string{addr},
+						},
+					},
+				},
+			},
+		},
+	}
+	reply := &vmargs.BuildGenesisReply{}
+	require.NoError(t, BuildGenesis(args, reply))
+
+	parseArgs := &vmargs.ParseGenesisArgs{
+		Bytes:    reply.Bytes,
+		Encoding: reply.Encoding,
+	}
+	parseReply := &vmargs.ParseGenesisReply{}
+	require.NoError(t, ParseGenesis(parseArgs, parseReply))
+	require.Len(t, parseReply.GenesisData["asset1"].InitialState["nft"], 1)
+}
+
+// addrBytes decodes the short ID bytes out of a bech32 address, ignoring
+// its human-readable part, so round-trip tests don't depend on which HRP
+// ParseGenesis chooses for a given (possibly zero-value) NetworkID.
+func addrBytes(t *testing.T, addrStr string) []byte {
+	t.Helper()
+	_, b, err := formatting.ParseBech32(addrStr)
+	require.NoError(t, err)
+	return b
+}
+
+func TestParseGenesisRoundTrip(t *testing.T) {
+	addr1 := testAddress(t, 1)
+	addr2 := testAddress(t, 2)
+
+	tests := []struct {
+		name       string
+		stateType  string
+		state      map[string]interface{}
+		checkAsset func(t *testing.T, def vmargs.AssetDefinition)
+	}{
+		{
+			name:      "fixedCap holder",
+			stateType: "fixedCap",
+			state: map[string]interface{}{
+				"amount":    "1000",
+				"addresses": []string{addr1},
+				"threshold": 1,
+			},
+			checkAsset: func(t *testing.T, def vmargs.AssetDefinition) {
+				holders := def.InitialState["fixedCap"]
+				require.Len(t, holders, 1)
+				holder := holders[0].(vmargs.Holder)
+				require.EqualValues(t, 1000, holder.Amount)
+				require.Len(t, holder.Addresses, 1)
+				require.Equal(t, addrBytes(t, addr1), addrBytes(t, holder.Addresses[0]))
+			},
+		},
+		{
+			name:      "locked fixedCap holder",
+			stateType: "fixedCap",
+			state: map[string]interface{}{
+				"amount":    "1000",
+				"addresses": []string{addr1},
+				"locktime":  12345,
+			},
+			checkAsset: func(t *testing.T, def vmargs.AssetDefinition) {
+				holders := def.InitialState["fixedCap"]
+				require.Len(t, holders, 1)
+				holder := holders[0].(vmargs.Holder)
+				require.EqualValues(t, 1000, holder.Amount)
+				require.EqualValues(t, 12345, holder.Locktime)
+			},
+		},
+		{
+			name:      "variableCap owners",
+			stateType: "variableCap",
+			state: map[string]interface{}{
+				"minters":   []string{addr1, addr2},
+				"threshold": 2,
+			},
+			checkAsset: func(t *testing.T, def vmargs.AssetDefinition) {
+				ownersList := def.InitialState["variableCap"]
+				require.Len(t, ownersList, 1)
+				owners := ownersList[0].(vmargs.Owners)
+				require.EqualValues(t, 2, owners.Threshold)
+				require.Len(t, owners.Minters, 2)
+			},
+		},
+		{
+			name:      "nft holder",
+			stateType: "nft",
+			state: map[string]interface{}{
+				"groupID": 7,
+				"payload": "0x68656c6c6f",
+				"owners":  []string{addr1},
+			},
+			checkAsset: func(t *testing.T, def vmargs.AssetDefinition) {
+				nfts := def.InitialState["nft"]
+				require.Len(t, nfts, 1)
+				nft := nfts[0].(vmargs.NFTHolder)
+				require.EqualValues(t, 7, nft.GroupID)
+				require.Equal(t, "0x68656c6c6f", nft.Payload)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := &vmargs.BuildGenesisArgs{
+				Encoding: formatting.Hex,
+				GenesisData: map[string]vmargs.AssetDefinition{
+					"asset1": {
+						Name:   "asset1",
+						Symbol: "AST",
+						Memo:   "0x",
+						InitialState: map[string][]interface{}{
+							tt.stateType: {tt.state},
+						},
+					},
+				},
+			}
+			reply := &vmargs.BuildGenesisReply{}
+			require.NoError(t, BuildGenesis(args, reply))
+
+			parseReply := &vmargs.ParseGenesisReply{}
+			err := ParseGenesis(&vmargs.ParseGenesisArgs{
+				Bytes:    reply.Bytes,
+				Encoding: reply.Encoding,
+			}, parseReply)
+			require.NoError(t, err)
+
+			def, ok := parseReply.GenesisData["asset1"]
+			require.True(t, ok)
+			tt.checkAsset(t, def)
+		})
+	}
+}
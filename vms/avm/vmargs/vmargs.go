@@ -0,0 +1,88 @@
+// Package vmargs defines the request and reply schemas for the AVM's static
+// service, and the JSON shapes submitted as genesis asset definitions.
+package vmargs
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/json"
+)
+
+// BuildGenesisArgs are the arguments to BuildGenesis.
+type BuildGenesisArgs struct {
+	NetworkID json.Uint32 `json:"networkID"`
+	// NetworkStartTime is the unix time, in seconds, the network is expected
+	// to start at. Locked holders may not unlock before this time.
+	NetworkStartTime int64                      `json:"networkStartTime"`
+	GenesisData      map[string]AssetDefinition `json:"genesisData"`
+	Encoding         formatting.Encoding        `json:"encoding"`
+	// ExpectedGenesisID, when non-empty, must match the GenesisID BuildGenesis
+	// computes or the call fails - this lets operators coordinating a subnet
+	// across machines confirm they all produced the same genesis.
+	ExpectedGenesisID ids.ID `json:"expectedGenesisID"`
+}
+
+// BuildGenesisReply is the reply from BuildGenesis.
+type BuildGenesisReply struct {
+	Bytes     string              `json:"bytes"`
+	Encoding  formatting.Encoding `json:"encoding"`
+	GenesisID ids.ID              `json:"genesisID"`
+}
+
+// AssetDefinition describes an asset to create at genesis.
+type AssetDefinition struct {
+	Name         string                   `json:"name"`
+	Symbol       string                   `json:"symbol"`
+	Denomination json.Uint32              `json:"denomination"`
+	Memo         string                   `json:"memo"`
+	InitialState map[string][]interface{} `json:"initialState"`
+	// MaxSupply, when non-zero, caps the total amount of fixedCap holdings
+	// BuildGenesis will allow for this asset.
+	MaxSupply json.Uint64 `json:"maxSupply"`
+}
+
+// Holder describes an address (or set of addresses) receiving an amount of a
+// fixedCap asset at genesis.
+type Holder struct {
+	Amount json.Uint64 `json:"amount"`
+	// Addresses are the addresses that jointly own the holding. A single
+	// address is the common case; more than one makes the holding a
+	// multisig output.
+	Addresses []string `json:"addresses"`
+	// Threshold is the number of Addresses signatures required to spend the
+	// holding. Defaults to 1 when unset.
+	Threshold uint32 `json:"threshold"`
+	// Locktime, when non-zero, is the unix time before which the holding
+	// cannot be spent.
+	Locktime uint64 `json:"locktime"`
+}
+
+// Owners describes the addresses allowed to mint more of a variableCap
+// asset after genesis.
+type Owners struct {
+	Minters   []string `json:"minters"`
+	Threshold uint32   `json:"threshold"`
+}
+
+// NFTHolder describes an NFT, identified by GroupID, minted to a set of
+// owners at genesis.
+type NFTHolder struct {
+	GroupID   uint32   `json:"groupID"`
+	Payload   string   `json:"payload"`
+	Owners    []string `json:"owners"`
+	Threshold uint32   `json:"threshold"`
+}
+
+// ParseGenesisArgs are the arguments to ParseGenesis.
+type ParseGenesisArgs struct {
+	NetworkID json.Uint32         `json:"networkID"`
+	Bytes     string              `json:"bytes"`
+	Encoding  formatting.Encoding `json:"encoding"`
+}
+
+// ParseGenesisReply is the reply from ParseGenesis: the GenesisData schema
+// that, fed back into BuildGenesis, reproduces the parsed genesis bytes.
+type ParseGenesisReply struct {
+	GenesisData map[string]AssetDefinition `json:"genesisData"`
+	Encoding    formatting.Encoding        `json:"encoding"`
+}